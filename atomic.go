@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// writeAtomic writes b to tmpPath and renames it into place at fnlPath.
+// When sync is true the temp file is fsynced before the rename and the
+// parent directory is fsynced after it, so a crash mid-commit can't leave a
+// torn file; when sync is false neither fsync is done.
+// The Windows-specific replace semantics live in atomic_windows.go.
+func writeAtomic(tmpPath, fnlPath string, b []byte, sync bool) error {
+	f, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return fmt.Errorf("opening temp file: %w", err)
+	}
+
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return fmt.Errorf("writing temp file: %w", err)
+	}
+
+	if sync {
+		if err := f.Sync(); err != nil {
+			f.Close()
+			return fmt.Errorf("syncing temp file: %w", err)
+		}
+	}
+
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("closing temp file: %w", err)
+	}
+
+	if err := renameReplace(tmpPath, fnlPath); err != nil {
+		return fmt.Errorf("renaming temp file: %w", err)
+	}
+
+	if !sync {
+		return nil
+	}
+
+	return syncDir(filepath.Dir(fnlPath))
+}
+
+// syncDir fsyncs a directory so a rename performed inside it is durable
+// across a crash. It is a no-op (returning nil) on platforms that don't
+// support fsyncing directories.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return fmt.Errorf("opening directory %q: %w", dir, err)
+	}
+	defer d.Close()
+
+	if err := d.Sync(); err != nil && !isDirSyncUnsupported(err) {
+		return fmt.Errorf("syncing directory %q: %w", dir, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,18 @@
+//go:build !windows
+
+package main
+
+import "os"
+
+// renameReplace renames oldPath to newPath. On POSIX, os.Rename already
+// atomically replaces an existing destination.
+func renameReplace(oldPath, newPath string) error {
+	return os.Rename(oldPath, newPath)
+}
+
+// isDirSyncUnsupported reports whether err is the expected failure mode for
+// fsyncing a directory; POSIX supports it, so nothing is ever unsupported
+// here.
+func isDirSyncUnsupported(err error) bool {
+	return false
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteAtomicReplacesExistingFile(t *testing.T) {
+	dir := t.TempDir()
+	fnlPath := filepath.Join(dir, "record.json")
+	tmpPath := fnlPath + ".tmp"
+
+	if err := os.WriteFile(fnlPath, []byte("old"), 0644); err != nil {
+		t.Fatalf("seeding existing file: %v", err)
+	}
+
+	if err := writeAtomic(tmpPath, fnlPath, []byte("new"), true); err != nil {
+		t.Fatalf("writeAtomic: %v", err)
+	}
+
+	b, err := os.ReadFile(fnlPath)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if string(b) != "new" {
+		t.Fatalf("got %q, want %q", b, "new")
+	}
+
+	if _, err := os.Stat(tmpPath); !os.IsNotExist(err) {
+		t.Fatalf("temp file %q should have been renamed away, stat err = %v", tmpPath, err)
+	}
+}
+
+func TestDriverWriteWithSync(t *testing.T) {
+	db, err := New(t.TempDir(), &Options{Sync: true})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	john := User{Name: "John", Age: "25"}
+	if err := db.Write("users", john.Name, john); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	var got User
+	if err := db.Read("users", "John", &got); err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if got.Name != "John" {
+		t.Fatalf("got %+v, want Name=John", got)
+	}
+}
@@ -0,0 +1,39 @@
+//go:build windows
+
+package main
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// renameReplace renames oldPath to newPath. Plain os.Rename fails on Windows
+// when newPath already exists, so this uses MoveFileEx with
+// MOVEFILE_REPLACE_EXISTING, matching the POSIX rename-replaces-destination
+// semantics Write relies on.
+func renameReplace(oldPath, newPath string) error {
+	oldPtr, err := windows.UTF16PtrFromString(oldPath)
+	if err != nil {
+		return err
+	}
+
+	newPtr, err := windows.UTF16PtrFromString(newPath)
+	if err != nil {
+		return err
+	}
+
+	return windows.MoveFileEx(oldPtr, newPtr, windows.MOVEFILE_REPLACE_EXISTING)
+}
+
+// isDirSyncUnsupported reports whether err is Windows' ERROR_ACCESS_DENIED,
+// returned when fsyncing a plain directory handle since Windows has no
+// equivalent of POSIX directory fsync.
+func isDirSyncUnsupported(err error) bool {
+	pathErr, ok := err.(*os.PathError)
+	if !ok {
+		return false
+	}
+
+	return pathErr.Err == windows.ERROR_ACCESS_DENIED
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"encoding/json"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Codec controls how records are marshalled to and from disk, including the
+// file extension used to store them. Swap in a different Codec via
+// Options.Codec to change the on-disk format without touching callers of
+// Write/Read/ReadAll/Delete.
+type Codec interface {
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+	Extension() string
+}
+
+// JSONCodec is the default Codec, matching the driver's original behaviour:
+// pretty-printed JSON with a trailing newline.
+type JSONCodec struct{}
+
+func (JSONCodec) Marshal(v interface{}) ([]byte, error) {
+	b, err := json.MarshalIndent(v, "", "\t")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(b, byte('\n')), nil
+}
+
+func (JSONCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+func (JSONCodec) Extension() string {
+	return ".json"
+}
+
+// BSONCodec stores records as compact BSON binary instead of JSON text, for
+// callers who care more about size and parse speed than human readability.
+type BSONCodec struct{}
+
+func (BSONCodec) Marshal(v interface{}) ([]byte, error) {
+	return bson.Marshal(v)
+}
+
+func (BSONCodec) Unmarshal(data []byte, v interface{}) error {
+	return bson.Unmarshal(data, v)
+}
+
+func (BSONCodec) Extension() string {
+	return ".bson"
+}
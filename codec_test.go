@@ -0,0 +1,40 @@
+package main
+
+import "testing"
+
+func TestCodecExtensions(t *testing.T) {
+	if ext := (JSONCodec{}).Extension(); ext != ".json" {
+		t.Fatalf("JSONCodec.Extension() = %q, want .json", ext)
+	}
+
+	if ext := (BSONCodec{}).Extension(); ext != ".bson" {
+		t.Fatalf("BSONCodec.Extension() = %q, want .bson", ext)
+	}
+}
+
+func TestDriverWriteReadWithBSONCodec(t *testing.T) {
+	db, err := New(t.TempDir(), &Options{Codec: BSONCodec{}})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	john := User{
+		Name:    "John",
+		Age:     "25",
+		Contact: "1234567890",
+		Company: "ABC",
+		Address: Address{City: "Negros Oriental", State: "Unitary", Country: "Philippines", Pincode: "1770"},
+	}
+	if err := db.Write("users", john.Name, john); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	var got User
+	if err := db.Read("users", "John", &got); err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+
+	if got.Company != "ABC" {
+		t.Fatalf("got %+v, want Company=ABC", got)
+	}
+}
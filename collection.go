@@ -0,0 +1,226 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// IndexBuilder derives a secondary index key from a record. Collection
+// maintains, for every distinct key it returns, the set of record ids that
+// produced it.
+type IndexBuilder[T any] func(v T) string
+
+// Collection is a typed view over a single Driver collection. It replaces
+// the raw []string slices returned by Driver.ReadAll with a real query layer
+// while still storing each record as one file on disk via the Driver.
+type Collection[T any] struct {
+	driver *Driver
+	name   string
+
+	indexBuilder IndexBuilder[T]
+	indexMutex   sync.RWMutex
+	index        map[string][]string // index key -> record ids
+}
+
+// indexResource is the sidecar record the secondary index is persisted
+// under, so a restart doesn't require a full rescan of the collection. It
+// lives in its own reserved directory alongside the database's collections,
+// not inside the collection directory itself, so Driver.ReadAll/ForEach/
+// ReadPage never trip over it while scanning real records.
+const indexResource = "index"
+
+// indexCollection is the (synthetic) Driver collection the sidecar is
+// written under via the normal Driver.Read/Write path, reusing its locking
+// and codec instead of hand-rolling file I/O.
+func (c *Collection[T]) indexCollection() string {
+	return filepath.Join(".indexes", c.name)
+}
+
+// NewCollection binds a typed Collection to collection name on d. If
+// indexBuilder is non-nil, a secondary index keyed by indexBuilder(v) is
+// maintained on every Insert/Update and persisted as a sidecar record; if no
+// sidecar exists yet it is built once by scanning the collection.
+func NewCollection[T any](d *Driver, name string, indexBuilder IndexBuilder[T]) (*Collection[T], error) {
+	c := &Collection[T]{
+		driver:       d,
+		name:         name,
+		indexBuilder: indexBuilder,
+		index:        make(map[string][]string),
+	}
+
+	if indexBuilder == nil {
+		return c, nil
+	}
+
+	if err := c.driver.Read(c.indexCollection(), indexResource, &c.index); err != nil {
+		if err := c.rebuildIndex(); err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+func (c *Collection[T]) saveIndex() error {
+	c.indexMutex.RLock()
+	defer c.indexMutex.RUnlock()
+
+	return c.driver.Write(c.indexCollection(), indexResource, c.index)
+}
+
+// rebuildIndex scans every record file in the collection directory directly,
+// since Driver.ReadAll only hands back raw bytes and not the id each record
+// was stored under.
+func (c *Collection[T]) rebuildIndex() error {
+	dir := filepath.Join(c.driver.dir, c.name)
+
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return c.saveIndex()
+		}
+		return err
+	}
+
+	index := make(map[string][]string)
+	ext := c.driver.codec.Extension()
+
+	for _, file := range files {
+		id := strings.TrimSuffix(file.Name(), ext)
+
+		var v T
+		if err := c.driver.Read(c.name, id, &v); err != nil {
+			return err
+		}
+
+		key := c.indexBuilder(v)
+		index[key] = append(index[key], id)
+	}
+
+	c.indexMutex.Lock()
+	c.index = index
+	c.indexMutex.Unlock()
+
+	return c.saveIndex()
+}
+
+// Insert stores v under id, updating the secondary index if one is
+// configured.
+func (c *Collection[T]) Insert(id string, v T) error {
+	var old T
+	hadOld := c.indexBuilder != nil && c.driver.Read(c.name, id, &old) == nil
+
+	if err := c.driver.Write(c.name, id, v); err != nil {
+		return err
+	}
+
+	if c.indexBuilder == nil {
+		return nil
+	}
+
+	if hadOld {
+		c.removeFromIndex(id, old)
+	}
+	c.addToIndex(id, v)
+	return c.saveIndex()
+}
+
+// Get returns the record stored under id.
+func (c *Collection[T]) Get(id string) (T, error) {
+	var v T
+	err := c.driver.Read(c.name, id, &v)
+	return v, err
+}
+
+// All returns every record in the collection.
+func (c *Collection[T]) All() ([]T, error) {
+	raws, err := c.driver.ReadAll(c.name)
+	if err != nil {
+		return nil, err
+	}
+
+	all := make([]T, 0, len(raws))
+	for _, raw := range raws {
+		var v T
+		if err := c.driver.codec.Unmarshal([]byte(raw), &v); err != nil {
+			return nil, err
+		}
+
+		all = append(all, v)
+	}
+
+	return all, nil
+}
+
+// Find returns every record for which predicate returns true.
+func (c *Collection[T]) Find(predicate func(T) bool) ([]T, error) {
+	all, err := c.All()
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []T
+	for _, v := range all {
+		if predicate(v) {
+			matches = append(matches, v)
+		}
+	}
+
+	return matches, nil
+}
+
+// Update loads the record stored under id, applies fn to it, and writes the
+// result back, keeping the secondary index in sync.
+func (c *Collection[T]) Update(id string, fn func(T) T) error {
+	v, err := c.Get(id)
+	if err != nil {
+		return err
+	}
+
+	updated := fn(v)
+	if err := c.driver.Write(c.name, id, updated); err != nil {
+		return err
+	}
+
+	if c.indexBuilder == nil {
+		return nil
+	}
+
+	c.removeFromIndex(id, v)
+	c.addToIndex(id, updated)
+	return c.saveIndex()
+}
+
+func (c *Collection[T]) addToIndex(id string, v T) {
+	key := c.indexBuilder(v)
+
+	c.indexMutex.Lock()
+	defer c.indexMutex.Unlock()
+	c.index[key] = append(c.index[key], id)
+}
+
+func (c *Collection[T]) removeFromIndex(id string, v T) {
+	key := c.indexBuilder(v)
+
+	c.indexMutex.Lock()
+	defer c.indexMutex.Unlock()
+
+	ids := c.index[key]
+	for i, existing := range ids {
+		if existing == id {
+			c.index[key] = append(ids[:i], ids[i+1:]...)
+			break
+		}
+	}
+}
+
+// ByIndex returns the ids recorded under key in the secondary index.
+func (c *Collection[T]) ByIndex(key string) []string {
+	c.indexMutex.RLock()
+	defer c.indexMutex.RUnlock()
+
+	return c.index[key]
+}
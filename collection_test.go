@@ -0,0 +1,147 @@
+package main
+
+import "testing"
+
+func TestCollectionInsertGetUpdate(t *testing.T) {
+	db, err := New(t.TempDir(), nil)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	byCompany := func(u User) string { return string(u.Company) }
+
+	users, err := NewCollection[User](db, "users", byCompany)
+	if err != nil {
+		t.Fatalf("NewCollection: %v", err)
+	}
+
+	john := User{Name: "John", Age: "25", Company: "ABC"}
+	if err := users.Insert(john.Name, john); err != nil {
+		t.Fatalf("Insert: %v", err)
+	}
+
+	got, err := users.Get("John")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Company != "ABC" {
+		t.Fatalf("Get returned %+v, want Company=ABC", got)
+	}
+
+	if err := users.Update("John", func(u User) User {
+		u.Company = "Google"
+		return u
+	}); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	got, err = users.Get("John")
+	if err != nil {
+		t.Fatalf("Get after Update: %v", err)
+	}
+	if got.Company != "Google" {
+		t.Fatalf("got Company=%s, want Google", got.Company)
+	}
+
+	ids := users.ByIndex("Google")
+	if len(ids) != 1 || ids[0] != "John" {
+		t.Fatalf("ByIndex(Google) = %v, want [John]", ids)
+	}
+
+	all, err := users.All()
+	if err != nil {
+		t.Fatalf("All: %v", err)
+	}
+	if len(all) != 1 {
+		t.Fatalf("All returned %d records, want 1 (sidecar index must not leak in)", len(all))
+	}
+}
+
+func TestCollectionFind(t *testing.T) {
+	db, err := New(t.TempDir(), nil)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	users, err := NewCollection[User](db, "users", nil)
+	if err != nil {
+		t.Fatalf("NewCollection: %v", err)
+	}
+
+	seed := []User{
+		{Name: "John", Company: "ABC"},
+		{Name: "Paul", Company: "Google"},
+		{Name: "Vince", Company: "ABC"},
+	}
+	for _, u := range seed {
+		if err := users.Insert(u.Name, u); err != nil {
+			t.Fatalf("Insert(%s): %v", u.Name, err)
+		}
+	}
+
+	matches, err := users.Find(func(u User) bool { return u.Company == "ABC" })
+	if err != nil {
+		t.Fatalf("Find: %v", err)
+	}
+
+	if len(matches) != 2 {
+		t.Fatalf("Find(Company==ABC) returned %d records, want 2: %+v", len(matches), matches)
+	}
+	for _, u := range matches {
+		if u.Company != "ABC" {
+			t.Fatalf("Find(Company==ABC) returned non-matching record %+v", u)
+		}
+	}
+
+	none, err := users.Find(func(u User) bool { return u.Company == "Nope" })
+	if err != nil {
+		t.Fatalf("Find (no match): %v", err)
+	}
+	if len(none) != 0 {
+		t.Fatalf("Find(Company==Nope) returned %d records, want 0", len(none))
+	}
+}
+
+func TestCollectionReopenLoadsIndex(t *testing.T) {
+	dir := t.TempDir()
+
+	db, err := New(dir, nil)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	byCompany := func(u User) string { return u.Company }
+
+	first, err := NewCollection[User](db, "users", byCompany)
+	if err != nil {
+		t.Fatalf("NewCollection: %v", err)
+	}
+
+	if err := first.Insert("John", User{Name: "John", Company: "ABC"}); err != nil {
+		t.Fatalf("Insert: %v", err)
+	}
+
+	// Reopen against the same directory with a fresh Driver and Collection,
+	// as if the process had restarted.
+	db2, err := New(dir, nil)
+	if err != nil {
+		t.Fatalf("New (reopen): %v", err)
+	}
+
+	second, err := NewCollection[User](db2, "users", byCompany)
+	if err != nil {
+		t.Fatalf("NewCollection (reopen): %v", err)
+	}
+
+	got, err := second.Get("John")
+	if err != nil {
+		t.Fatalf("Get after reopen: %v", err)
+	}
+	if got.Name != "John" {
+		t.Fatalf("got %+v, want Name=John", got)
+	}
+
+	if ids := second.ByIndex("ABC"); len(ids) != 1 || ids[0] != "John" {
+		t.Fatalf("ByIndex(ABC) after reopen = %v, want [John]", ids)
+	}
+}
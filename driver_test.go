@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// TestConcurrentReadWrite spawns many concurrent writers against the same
+// collection alongside a reader, mirroring the external TestBasic harness.
+// It exercises the per-collection RWMutex: writers must serialize against
+// each other and the reader, but the reader must not deadlock behind them.
+func TestConcurrentReadWrite(t *testing.T) {
+	db, err := New(t.TempDir(), nil)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	const writers = 50
+
+	var wg sync.WaitGroup
+	wg.Add(writers)
+
+	for i := 0; i < writers; i++ {
+		go func(i int) {
+			defer wg.Done()
+			user := User{Name: fmt.Sprintf("user-%d", i), Age: "30"}
+			if err := db.Write("users", user.Name, user); err != nil {
+				t.Errorf("Write: %v", err)
+			}
+		}(i)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < writers; i++ {
+			db.ReadAll("users")
+		}
+	}()
+
+	wg.Wait()
+	<-done
+
+	records, err := db.ReadAll("users")
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+
+	if len(records) != writers {
+		t.Fatalf("got %d records, want %d", len(records), writers)
+	}
+}
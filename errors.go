@@ -0,0 +1,30 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Sentinel errors returned by Driver methods so callers can branch with
+// errors.Is instead of matching on error strings.
+var (
+	ErrMissingCollection = errors.New("missing collection - no place to save record")
+	ErrMissingResource   = errors.New("missing resource - unable to save record")
+	ErrNotFound          = errors.New("resource not found")
+)
+
+// NotFoundError is returned by Read and Delete when the requested resource
+// does not exist. It wraps ErrNotFound so errors.Is(err, ErrNotFound) still
+// works while preserving the collection/resource that was looked up.
+type NotFoundError struct {
+	Collection string
+	Resource   string
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("resource %q not found in collection %q", e.Resource, e.Collection)
+}
+
+func (e *NotFoundError) Unwrap() error {
+	return ErrNotFound
+}
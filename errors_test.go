@@ -0,0 +1,76 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWriteMissingCollectionAndResource(t *testing.T) {
+	db, err := New(t.TempDir(), nil)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	if err := db.Write("", "John", User{}); !errors.Is(err, ErrMissingCollection) {
+		t.Fatalf("Write with empty collection: got %v, want ErrMissingCollection", err)
+	}
+
+	if err := db.Write("users", "", User{}); !errors.Is(err, ErrMissingResource) {
+		t.Fatalf("Write with empty resource: got %v, want ErrMissingResource", err)
+	}
+}
+
+func TestReadDeleteNotFound(t *testing.T) {
+	db, err := New(t.TempDir(), nil)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	var u User
+	err = db.Read("users", "Missing", &u)
+	var notFound *NotFoundError
+	if !errors.As(err, &notFound) {
+		t.Fatalf("Read of missing resource: got %v, want *NotFoundError", err)
+	}
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Read of missing resource: got %v, want errors.Is(err, ErrNotFound)", err)
+	}
+
+	err = db.Delete("users", "Missing")
+	if !errors.As(err, &notFound) {
+		t.Fatalf("Delete of missing resource: got %v, want *NotFoundError", err)
+	}
+}
+
+func TestDeleteMissingCollectionAndResource(t *testing.T) {
+	db, err := New(t.TempDir(), nil)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	if err := db.Write("users", "John", User{Name: "John"}); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := db.Write("accounts", "Acme", User{Name: "Acme"}); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	if err := db.Delete("", ""); !errors.Is(err, ErrMissingCollection) {
+		t.Fatalf("Delete(\"\", \"\"): got %v, want ErrMissingCollection", err)
+	}
+
+	if err := db.Delete("users", ""); !errors.Is(err, ErrMissingResource) {
+		t.Fatalf("Delete(users, \"\"): got %v, want ErrMissingResource", err)
+	}
+
+	// The guards above must reject before any directory is touched: both
+	// collections written before the Delete("", "") call should still be
+	// readable.
+	var got User
+	if err := db.Read("users", "John", &got); err != nil {
+		t.Fatalf("Read(users, John) after Delete(\"\", \"\"): %v", err)
+	}
+	if err := db.Read("accounts", "Acme", &got); err != nil {
+		t.Fatalf("Read(accounts, Acme) after Delete(\"\", \"\"): %v", err)
+	}
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// ForEach streams the records of collections one at a time, invoking fn with
+// each record's resource name (without the codec extension) and raw bytes.
+// It stops and returns early as soon as fn returns a non-nil error, unlike
+// ReadAll which always loads the whole collection into memory first.
+func (d *Driver) ForEach(collections string, fn func(name string, raw []byte) error) error {
+	if collections == "" {
+		return ErrMissingCollection
+	}
+
+	mutex := d.getOrCreateMutext(collections)
+	mutex.RLock()
+	defer mutex.RUnlock()
+
+	dir := filepath.Join(d.dir, collections)
+
+	if _, err := d.stat(dir); err != nil {
+		if os.IsNotExist(err) {
+			return &NotFoundError{Collection: collections}
+		}
+		return fmt.Errorf("statting collection: %w", err)
+	}
+
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("reading collection directory: %w", err)
+	}
+
+	for _, file := range files {
+		b, err := ioutil.ReadFile(filepath.Join(dir, file.Name()))
+		if err != nil {
+			return fmt.Errorf("reading record: %w", err)
+		}
+
+		name := file.Name()
+		if ext := d.codec.Extension(); filepath.Ext(name) == ext {
+			name = name[:len(name)-len(ext)]
+		}
+
+		if err := fn(name, b); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ReadPage returns up to limit records from collections, skipping the first
+// offset, for callers that want bounded reads instead of the whole
+// collection via ReadAll.
+func (d *Driver) ReadPage(collections string, offset, limit int) ([]string, error) {
+	var page []string
+	i := 0
+
+	err := d.ForEach(collections, func(name string, raw []byte) error {
+		if i >= offset && len(page) < limit {
+			page = append(page, string(raw))
+		}
+		i++
+
+		if len(page) >= limit {
+			return errStopForEach
+		}
+
+		return nil
+	})
+
+	if err != nil && !errors.Is(err, errStopForEach) {
+		return nil, err
+	}
+
+	return page, nil
+}
+
+// errStopForEach is an internal sentinel ForEach callbacks use to end
+// iteration early without it being mistaken for a real failure.
+var errStopForEach = errors.New("stop iteration")
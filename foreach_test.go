@@ -0,0 +1,76 @@
+package main
+
+import "testing"
+
+func seedUsers(t *testing.T, db *Driver, names ...string) {
+	t.Helper()
+	for _, name := range names {
+		if err := db.Write("users", name, User{Name: name}); err != nil {
+			t.Fatalf("Write(%s): %v", name, err)
+		}
+	}
+}
+
+func TestForEachVisitsEveryRecord(t *testing.T) {
+	db, err := New(t.TempDir(), nil)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	seedUsers(t, db, "John", "Paul", "Vince")
+
+	seen := map[string]bool{}
+	err = db.ForEach("users", func(name string, raw []byte) error {
+		seen[name] = true
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ForEach: %v", err)
+	}
+
+	for _, name := range []string{"John", "Paul", "Vince"} {
+		if !seen[name] {
+			t.Fatalf("ForEach never visited %q, saw %v", name, seen)
+		}
+	}
+}
+
+func TestForEachStopsOnError(t *testing.T) {
+	db, err := New(t.TempDir(), nil)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	seedUsers(t, db, "John", "Paul", "Vince")
+
+	visited := 0
+	stop := errStopForEach
+	err = db.ForEach("users", func(name string, raw []byte) error {
+		visited++
+		return stop
+	})
+
+	if err != stop {
+		t.Fatalf("ForEach returned %v, want the callback's error", err)
+	}
+	if visited != 1 {
+		t.Fatalf("ForEach visited %d records before stopping, want 1", visited)
+	}
+}
+
+func TestReadPageBounds(t *testing.T) {
+	db, err := New(t.TempDir(), nil)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	seedUsers(t, db, "John", "Paul", "Vince", "Leah", "Dee")
+
+	page, err := db.ReadPage("users", 1, 2)
+	if err != nil {
+		t.Fatalf("ReadPage: %v", err)
+	}
+	if len(page) != 2 {
+		t.Fatalf("got %d records, want 2", len(page))
+	}
+}
@@ -25,14 +25,22 @@ type (
 
 	Driver struct {
 		Mutex   sync.Mutex
-		mutexes map[string]*sync.Mutex
+		mutexes map[string]*sync.RWMutex
 		dir     string
 		log     Logger
+		codec   Codec
+		sync    bool
 	}
 )
 
 type Options struct {
 	Logger
+	Codec Codec
+
+	// Sync makes Write fsync each record (and its parent directory) before
+	// returning, trading write latency for durability against a crash
+	// mid-commit. Off by default.
+	Sync bool
 }
 
 func New(dir string, options *Options) (*Driver, error) {
@@ -46,10 +54,16 @@ func New(dir string, options *Options) (*Driver, error) {
 		opts.Logger = lumber.NewConsoleLogger((lumber.INFO))
 	}
 
+	if opts.Codec == nil {
+		opts.Codec = JSONCodec{}
+	}
+
 	driver := Driver{
 		dir:     dir,
-		mutexes: make(map[string]*sync.Mutex),
+		mutexes: make(map[string]*sync.RWMutex),
 		log:     opts.Logger,
+		codec:   opts.Codec,
+		sync:    opts.Sync,
 	}
 
 	if _, err := os.Stat(dir); err == nil {
@@ -65,11 +79,11 @@ func New(dir string, options *Options) (*Driver, error) {
 
 func (d *Driver) Write(collections, resource string, v interface{}) error {
 	if collections == "" {
-		return fmt.Errorf("missing collection - no place to save record")
+		return ErrMissingCollection
 	}
 
 	if resource == "" {
-		return fmt.Errorf("missing resource - unable to save record!")
+		return ErrMissingResource
 	}
 
 	mutex := d.getOrCreateMutext(collections)
@@ -77,58 +91,71 @@ func (d *Driver) Write(collections, resource string, v interface{}) error {
 	defer mutex.Unlock()
 
 	dir := filepath.Join(d.dir, collections)
-	fnlPath := filepath.Join(dir, resource+".json")
+	fnlPath := filepath.Join(dir, resource+d.codec.Extension())
 	tmpPath := fnlPath + ".tmp"
 
 	if err := os.MkdirAll(dir, 0755); err != nil {
-		return err
+		return fmt.Errorf("creating collection directory: %w", err)
 	}
 
-	b, err := json.MarshalIndent(v, "", "\t")
+	b, err := d.codec.Marshal(v)
 	if err != nil {
-		return err
+		return fmt.Errorf("marshalling record: %w", err)
 	}
 
-	b = append(b, byte('\n'))
-	if err := ioutil.WriteFile(tmpPath, b, 0644); err != nil {
-		return err
-	}
-
-	return os.Rename(tmpPath, fnlPath)
+	return writeAtomic(tmpPath, fnlPath, b, d.sync)
 }
 
 func (d *Driver) Read(collections, resource string, v interface{}) error {
 	if collections == "" {
-		return fmt.Errorf("missing collection - no place to save record")
+		return ErrMissingCollection
 	}
 
 	if resource == "" {
-		return fmt.Errorf("missing resource - unable to read record!")
+		return ErrMissingResource
 	}
 
-	record := filepath.Join(d.dir, collections)
+	mutex := d.getOrCreateMutext(collections)
+	mutex.RLock()
+	defer mutex.RUnlock()
 
-	if _, err := stat(record); err != nil {
-		return err
+	record := filepath.Join(d.dir, collections, resource)
+
+	if _, err := d.stat(record); err != nil {
+		if os.IsNotExist(err) {
+			return &NotFoundError{Collection: collections, Resource: resource}
+		}
+		return fmt.Errorf("statting record: %w", err)
 	}
 
-	b, err := ioutil.ReadFile(record + ".json")
+	b, err := ioutil.ReadFile(record + d.codec.Extension())
 	if err != nil {
-		return err
+		return fmt.Errorf("reading record: %w", err)
 	}
 
-	return json.Unmarshal(b, &v)
+	if err := d.codec.Unmarshal(b, v); err != nil {
+		return fmt.Errorf("unmarshalling record: %w", err)
+	}
+
+	return nil
 }
 
 func (d *Driver) ReadAll(collections string) ([]string, error) {
 	if collections == "" {
-		return nil, fmt.Errorf("missing collection - no place to save record")
+		return nil, ErrMissingCollection
 	}
 
+	mutex := d.getOrCreateMutext(collections)
+	mutex.RLock()
+	defer mutex.RUnlock()
+
 	dir := filepath.Join(d.dir, collections)
 
-	if _, err := stat(dir); err != nil {
-		return nil, err
+	if _, err := d.stat(dir); err != nil {
+		if os.IsNotExist(err) {
+			return nil, &NotFoundError{Collection: collections}
+		}
+		return nil, fmt.Errorf("statting collection: %w", err)
 	}
 
 	files, _ := ioutil.ReadDir(dir)
@@ -138,7 +165,7 @@ func (d *Driver) ReadAll(collections string) ([]string, error) {
 	for _, file := range files {
 		b, err := ioutil.ReadFile(filepath.Join(dir, file.Name()))
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("reading record: %w", err)
 		}
 
 		records = append(records, string(b))
@@ -149,6 +176,14 @@ func (d *Driver) ReadAll(collections string) ([]string, error) {
 }
 
 func (d *Driver) Delete(collections, resource string) error {
+	if collections == "" {
+		return ErrMissingCollection
+	}
+
+	if resource == "" {
+		return ErrMissingResource
+	}
+
 	path := filepath.Join(collections, resource)
 	mutex := d.getOrCreateMutext(collections)
 	mutex.Lock()
@@ -156,38 +191,42 @@ func (d *Driver) Delete(collections, resource string) error {
 
 	dir := filepath.Join(d.dir, path)
 
-	switch fi, err := stat(dir); {
-	case fi == nil, err != nil:
-		return fmt.Errorf("unable to find file or directory name %v\n", path)
+	fi, err := d.stat(dir)
+	switch {
+	case err != nil:
+		if os.IsNotExist(err) {
+			return &NotFoundError{Collection: collections, Resource: resource}
+		}
+		return fmt.Errorf("statting record: %w", err)
 
 	case fi.Mode().IsDir():
 		return os.RemoveAll(dir)
 
 	case fi.Mode().IsRegular():
-		return os.RemoveAll(dir + ".json")
+		return os.RemoveAll(dir + d.codec.Extension())
 	}
 
 	return nil
 
 }
 
-func (d *Driver) getOrCreateMutext(collections string) *sync.Mutex {
+func (d *Driver) getOrCreateMutext(collections string) *sync.RWMutex {
 
 	d.Mutex.Lock()
 	defer d.Mutex.Unlock()
 	m, ok := d.mutexes[collections]
 
 	if !ok {
-		m = &sync.Mutex{}
+		m = &sync.RWMutex{}
 		d.mutexes[collections] = m
 	}
 
 	return m
 }
 
-func stat(path string) (fi os.FileInfo, err error) {
-	if fi, err = os.Stat(path); os.IsNotExist(err) {
-		fi, err = os.Stat(path + ".json")
+func (d *Driver) stat(path string) (fi os.FileInfo, err error) {
+	if fi, err = os.Stat(path + d.codec.Extension()); os.IsNotExist(err) {
+		fi, err = os.Stat(path)
 	}
 
 	return fi, err